@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"runtime"
+	"strings"
 	"sync"
 	"time"
 
@@ -21,8 +23,23 @@ type FilteringReader struct {
 	// original pattern, including if it is set to nil.
 	FilterPattern **regexp.Regexp
 
+	// When set, lines matching FilterPattern are hidden and lines not
+	// matching it are kept, like "grep -v".
+	Invert bool
+
+	// When set, FilterPattern is matched case insensitively. This is applied
+	// internally by recompiling the pattern with a "(?i)" prefix, so callers
+	// don't need to edit FilterPattern's source to get this behavior.
+	CaseInsensitive bool
+
+	// ContextBefore and ContextAfter say how many extra backing lines to pull
+	// in immediately before and after each match, like "grep -B"/"-A"/"-C".
+	// Those extra lines are included whether or not they themselves match.
+	ContextBefore int
+	ContextAfter  int
+
 	// Protects filteredLinesCache, unfilteredLineCountWhenCaching, and
-	// filterPatternWhenCaching.
+	// filterSpecWhenCaching.
 	lock sync.Mutex
 
 	// nil means no filtering has happened yet
@@ -33,28 +50,239 @@ type FilteringReader struct {
 	// rebuilt.
 	unfilteredLineCountWhenCaching int
 
-	// This is the pattern that was used when we cached the lines. If it
-	// doesn't match the current pattern, then our cache needs to be rebuilt.
-	filterPatternWhenCaching *regexp.Regexp
+	// This is the pattern / Invert / CaseInsensitive combination that was
+	// used when we cached the lines. If it doesn't match the current one,
+	// then our cache needs to be rebuilt.
+	filterSpecWhenCaching filterSpec
+
+	// ContextBefore/ContextAfter at the time we last cached. If they don't
+	// match the current values, our cache needs to be rebuilt even though
+	// the filter spec itself hasn't changed.
+	contextBeforeWhenCaching int
+	contextAfterWhenCaching  int
+
+	// Bumped every time a new scan starts, including by Cancel(). A running
+	// background scan compares its own copy of this against the current
+	// value before each write to filteredLinesCache; a mismatch means it has
+	// been superseded or cancelled, so it stops without touching the cache.
+	scanGeneration int
+
+	// True while a background goroutine is still filling in
+	// filteredLinesCache from the backing reader. While this is true,
+	// filteredLinesCache may only hold a prefix of the final result.
+	scanInProgress bool
+
+	// How far into the backing reader the in-progress scan has gotten, and
+	// how many backing lines it needs to get through in total. Only
+	// meaningful while scanInProgress is true.
+	scanLinesScanned int
+	scanLinesTotal   int
+}
+
+// filterSpec bundles everything that decides whether a backing line ends up
+// in the filtered view: the pattern to test it against (already recompiled
+// with "(?i)" if case insensitivity is on) and whether a match should be
+// kept or dropped.
+type filterSpec struct {
+	pattern *regexp.Regexp
+	invert  bool
+}
+
+// currentFilterSpec reads *f.FilterPattern, f.Invert and f.CaseInsensitive
+// and bundles them into the filterSpec that should be used right now.
+func (f *FilteringReader) currentFilterSpec() filterSpec {
+	pattern := *f.FilterPattern
+	if pattern != nil && f.CaseInsensitive {
+		if recompiled, err := regexp.Compile("(?i)" + pattern.String()); err == nil {
+			pattern = recompiled
+		}
+	}
+	return filterSpec{pattern: pattern, invert: f.Invert}
+}
+
+func (s filterSpec) patternSource() string {
+	if s.pattern == nil {
+		return ""
+	}
+	return s.pattern.String()
+}
+
+func (s filterSpec) isEmpty() bool {
+	return len(s.patternSource()) == 0
+}
+
+func (s filterSpec) sameAs(other filterSpec) bool {
+	return s.patternSource() == other.patternSource() && s.invert == other.invert
+}
+
+// isNarrowingOf reports whether every line matching s is guaranteed to also
+// match other, so that other's cached matches are a safe starting point to
+// filter down from instead of rescanning the whole backing reader.
+//
+// We only recognize one shape of narrowing: s's pattern source is other's
+// pattern source with a non-empty, purely literal suffix appended (e.g.
+// "apple" -> "apple pie", as typing a few more characters into the filter box
+// would produce). That's deliberately narrower than "new contains old as a
+// substring with no anchors/alternation", which looked plausible but wasn't:
+// inserting "?" into "ab" to get "ab?" contains "ab" as a substring and has
+// no anchors or alternation, yet "ab?" matches strictly more lines than "ab"
+// (anything with just "a" now qualifies too).
+//
+// Appending a literal suffix doesn't have that problem: regexp concatenation
+// means a match of other's pattern immediately followed by the literal text
+// is itself a substring match of other's pattern alone, regardless of what
+// anchors, quantifiers or alternation other's source contains. Requiring the
+// suffix to contain no regexp metacharacters (so it can't combine with the
+// last atom of other's pattern, e.g. turn "b" into "b?") is what keeps this
+// sound.
+func isNarrowingOf(s filterSpec, other filterSpec) bool {
+	if s.invert != other.invert {
+		return false
+	}
+
+	otherSource := other.patternSource()
+	sSource := s.patternSource()
+	if otherSource == "" || sSource == otherSource {
+		return false
+	}
+	if !strings.HasPrefix(sSource, otherSource) {
+		return false
+	}
+
+	suffix := sSource[len(otherSource):]
+	lastOther := otherSource[len(otherSource)-1]
+	if suffix != "" && suffix[0] >= '0' && suffix[0] <= '7' && lastOther >= '0' && lastOther <= '9' {
+		// A digit after a trailing octal escape extends that escape ("\0" ->
+		// "\01") and changes which character it matches.
+		return false
+	}
+	return suffix != "" && suffix == regexp.QuoteMeta(suffix)
+}
+
+// matches reports whether a line's plain text should be kept under this
+// filterSpec.
+//
+// Before handing the line to the regexp engine, we try to rule it out with a
+// cheap substring check on the pattern's literal prefix. Most lines in a log
+// file don't match the filter at all, and regexp.MatchString is a lot more
+// expensive than strings.Contains / strings.IndexByte, so this prefilter pays
+// for itself many times over.
+func (s filterSpec) matches(plain string) bool {
+	if s.isEmpty() {
+		return true
+	}
+
+	matched := lineMatchesPattern(plain, s.pattern)
+	if s.invert {
+		return !matched
+	}
+	return matched
+}
+
+func lineMatchesPattern(plain string, pattern *regexp.Regexp) bool {
+	// LiteralPrefix's "complete" return value means the literal prefix is
+	// the entire pattern source, not that the pattern is an unanchored
+	// literal search: "^abc$" also reports complete=true with prefix="abc",
+	// so a Contains("abc") match there doesn't mean the anchored pattern
+	// matches. The prefix check below is only ever a necessary condition,
+	// never sufficient; the real answer always comes from MatchString.
+	prefix, _ := pattern.LiteralPrefix()
+	if len(prefix) == 1 {
+		if strings.IndexByte(plain, prefix[0]) < 0 {
+			return false
+		}
+	} else if len(prefix) > 1 {
+		if !strings.Contains(plain, prefix) {
+			return false
+		}
+	}
+
+	return pattern.MatchString(plain)
 }
 
 // Please hold the lock when calling this method.
 func (f *FilteringReader) rebuildCache() {
-	t0 := time.Now()
+	newSpec := f.currentFilterSpec()
+	newUnfilteredLineCount := f.BackingReader.GetLineCount()
+
+	oldCache := f.filteredLinesCache
+	oldSpec := f.filterSpecWhenCaching
+	oldUnfilteredLineCount := f.unfilteredLineCountWhenCaching
+
+	// A cache built with context lines holds unmatched neighbours and "--"
+	// separators, so neither fast path below may start from it.
+	oldCacheHasNoContext := f.contextBeforeWhenCaching == 0 && f.contextAfterWhenCaching == 0
+
+	// Any scan already running is about to be replaced; bump the generation
+	// so it notices and stops writing to filteredLinesCache.
+	f.scanGeneration++
+
+	if f.ContextBefore > 0 || f.ContextAfter > 0 {
+		// Once unmatched neighbour lines can end up in the cache, "the old
+		// cache is a superset/subset of the new one" no longer holds, so the
+		// append/narrowing fast paths below aren't safe here.
+		f.rebuildCacheWithContext(newSpec, newUnfilteredLineCount)
+		return
+	}
 
-	cache := make([]*reader.NumberedLine, 0)
-	filterPattern := *f.FilterPattern
+	if !f.scanInProgress && oldCache != nil && oldCacheHasNoContext && newSpec.sameAs(oldSpec) && newUnfilteredLineCount >= oldUnfilteredLineCount {
+		// Only the line count grew, and the filter didn't change. Append
+		// rather than re-scanning everything we already matched. This is
+		// cheap enough to do synchronously.
+		//
+		// This is only safe when oldCache is itself complete: while a
+		// background scan is in flight, oldCache holds just the prefix
+		// scanned so far, and appending to it would silently drop every
+		// match in [scanLinesScanned, oldUnfilteredLineCount). In that case
+		// fall through to starting a fresh full rebuild instead.
+		f.appendToCache(*oldCache, oldUnfilteredLineCount, newUnfilteredLineCount, newSpec)
+		f.scanInProgress = false
+		return
+	}
+
+	if !f.scanInProgress && oldCache != nil && oldCacheHasNoContext && newUnfilteredLineCount == oldUnfilteredLineCount && isNarrowingOf(newSpec, oldSpec) {
+		// The backing reader didn't change, and the new pattern is a sound
+		// narrowing of the old one (see isNarrowingOf): every line in
+		// oldCache that doesn't match newSpec can be dropped, and nothing
+		// outside oldCache could possibly match newSpec either. Filtering
+		// down oldCache is cheap enough to do synchronously, same as the
+		// append path above.
+		f.rescanCache(*oldCache, newSpec)
+		f.scanInProgress = false
+		return
+	}
+
+	f.startFullRebuild(newSpec, newUnfilteredLineCount)
+}
 
-	// Mark cache base conditions
-	f.unfilteredLineCountWhenCaching = f.BackingReader.GetLineCount()
-	f.filterPatternWhenCaching = filterPattern
+// Cancel aborts an in-flight background scan, if any, without waiting for it
+// to notice, and throws away whatever partial result it had produced so far.
+// Without that, an unchanged filter/backing reader after Cancel() would make
+// the next GetLines/GetLineCount/GetLine return that partial prefix as a
+// finished 100% result instead of rebuilding it. The next call to any of
+// those methods will therefore always start a fresh scan from scratch
+// against whatever filter and backing reader state are current at that
+// point.
+func (f *FilteringReader) Cancel() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
 
-	// Repopulate the cache
-	allBaseLines := f.BackingReader.GetLines(linemetadata.Index{}, math.MaxInt)
-	resultIndex := 0
-	for _, line := range allBaseLines.Lines {
-		if filterPattern != nil && len(filterPattern.String()) > 0 && !filterPattern.MatchString(line.Line.Plain(&line.Index)) {
-			// We have a pattern but it doesn't match
+	f.scanGeneration++
+	f.scanInProgress = false
+	f.filteredLinesCache = nil
+}
+
+// Please hold the lock when calling this method.
+func (f *FilteringReader) appendToCache(oldCache []*reader.NumberedLine, fromLineCount int, toLineCount int, spec filterSpec) {
+	t0 := time.Now()
+
+	cache := make([]*reader.NumberedLine, len(oldCache))
+	copy(cache, oldCache)
+	resultIndex := len(cache)
+
+	newLines := f.BackingReader.GetLines(linemetadata.IndexFromZeroBased(fromLineCount), toLineCount-fromLineCount)
+	for _, line := range newLines.Lines {
+		if !spec.matches(line.Line.Plain(&line.Index)) {
 			continue
 		}
 
@@ -67,9 +295,281 @@ func (f *FilteringReader) rebuildCache() {
 	}
 
 	f.filteredLinesCache = &cache
+	f.unfilteredLineCountWhenCaching = toLineCount
+	f.filterSpecWhenCaching = spec
+	f.contextBeforeWhenCaching = 0
+	f.contextAfterWhenCaching = 0
+
+	log.Debugf("Appended %d new lines (%d..%d) to filter cache in %s",
+		toLineCount-fromLineCount, fromLineCount, toLineCount, time.Since(t0))
+}
+
+// Please hold the lock when calling this method. Used instead of a full
+// rebuild when spec is a sound narrowing of whatever produced oldCache (see
+// isNarrowingOf): oldCache is itself the complete set of lines spec could
+// possibly match, so filtering it down is enough.
+func (f *FilteringReader) rescanCache(oldCache []*reader.NumberedLine, spec filterSpec) {
+	t0 := time.Now()
+
+	cache := make([]*reader.NumberedLine, 0, len(oldCache))
+	for _, line := range oldCache {
+		if !spec.matches(line.Line.Plain(&line.Index)) {
+			continue
+		}
+
+		cache = append(cache, &reader.NumberedLine{
+			Line:   line.Line,
+			Index:  linemetadata.IndexFromZeroBased(len(cache)),
+			Number: line.Number,
+		})
+	}
+
+	f.filteredLinesCache = &cache
+	f.filterSpecWhenCaching = spec
+	f.contextBeforeWhenCaching = 0
+	f.contextAfterWhenCaching = 0
+
+	log.Debugf("Narrowed filter cache from %d to %d lines in %s",
+		len(oldCache), len(cache), time.Since(t0))
+}
+
+// Please hold the lock when calling this method. Used instead of the
+// append-only fast path whenever context lines are requested, since pulling
+// in unmatched neighbour lines means the old cache is no longer guaranteed
+// to be a subset of the new one.
+func (f *FilteringReader) rebuildCacheWithContext(spec filterSpec, unfilteredLineCount int) {
+	t0 := time.Now()
+
+	f.unfilteredLineCountWhenCaching = unfilteredLineCount
+	f.filterSpecWhenCaching = spec
+	f.contextBeforeWhenCaching = f.ContextBefore
+	f.contextAfterWhenCaching = f.ContextAfter
+	f.scanInProgress = false
+
+	allBaseLines := f.BackingReader.GetLines(linemetadata.Index{}, unfilteredLineCount)
+	cache := addContextLines(allBaseLines.Lines, spec, f.ContextBefore, f.ContextAfter)
+	f.filteredLinesCache = &cache
+
+	log.Debugf("Filtered with context to %d/%d lines in %s",
+		len(cache), len(allBaseLines.Lines), time.Since(t0))
+}
+
+// addContextLines returns the lines whose matches (per spec) are kept, plus
+// up to contextBefore/contextAfter unmatched neighbours around each match.
+// Overlapping windows are coalesced, and a synthetic separator line is
+// inserted between non-adjacent groups, the way "grep -C" prints "--"
+// between them. The separator's Number is nil so it doesn't perturb line
+// numbering in the gutter.
+func addContextLines(lines []*reader.NumberedLine, spec filterSpec, contextBefore int, contextAfter int) []*reader.NumberedLine {
+	included := make([]bool, len(lines))
+	anyMatch := false
+	for i, line := range lines {
+		if !spec.matches(line.Line.Plain(&line.Index)) {
+			continue
+		}
+		anyMatch = true
+
+		from := i - contextBefore
+		if from < 0 {
+			from = 0
+		}
+		to := i + contextAfter
+		if to > len(lines)-1 {
+			to = len(lines) - 1
+		}
+		for j := from; j <= to; j++ {
+			included[j] = true
+		}
+	}
+
+	if !anyMatch {
+		return make([]*reader.NumberedLine, 0)
+	}
+
+	cache := make([]*reader.NumberedLine, 0, len(lines))
+	previousIncludedLine := -1
+	for i, isIncluded := range included {
+		if !isIncluded {
+			continue
+		}
+
+		if previousIncludedLine != -1 && i != previousIncludedLine+1 {
+			cache = append(cache, contextSeparator(len(cache)))
+		}
+
+		line := lines[i]
+		cache = append(cache, &reader.NumberedLine{
+			Line:   line.Line,
+			Index:  linemetadata.IndexFromZeroBased(len(cache)),
+			Number: line.Number,
+		})
+		previousIncludedLine = i
+	}
+
+	return cache
+}
+
+// contextSeparator is a synthetic "--" line marking a gap between two
+// non-adjacent groups of context lines. Its Number is nil so callers can
+// recognize and style it differently from a real backing line.
+func contextSeparator(resultIndex int) *reader.NumberedLine {
+	return &reader.NumberedLine{
+		Line:   *linemetadata.NewLine("--"),
+		Index:  linemetadata.IndexFromZeroBased(resultIndex),
+		Number: nil,
+	}
+}
+
+// scanBatchLines caps how many backing lines a single step of a background
+// scan processes before checking in with the lock. Small enough to keep
+// GetLines responsive while a scan is running, large enough that the
+// parallel matching inside each batch is still worth it.
+const scanBatchLines = 4096
+
+// Please hold the lock when calling this method. Unlike appendToCache, this
+// kicks off a background goroutine and returns immediately with an empty
+// cache; the goroutine fills it in batch by batch so that GetLines never
+// blocks on a full scan of the backing reader.
+func (f *FilteringReader) startFullRebuild(spec filterSpec, unfilteredLineCount int) {
+	emptyCache := make([]*reader.NumberedLine, 0)
+	f.filteredLinesCache = &emptyCache
+	f.unfilteredLineCountWhenCaching = unfilteredLineCount
+	f.filterSpecWhenCaching = spec
+	f.contextBeforeWhenCaching = 0
+	f.contextAfterWhenCaching = 0
+	f.scanInProgress = unfilteredLineCount > 0
+	f.scanLinesScanned = 0
+	f.scanLinesTotal = unfilteredLineCount
+
+	if unfilteredLineCount == 0 {
+		return
+	}
+
+	generation := f.scanGeneration
+	go f.scanInBackground(generation, spec, unfilteredLineCount)
+}
+
+// scanInBackground does the actual work of startFullRebuild outside of the
+// caller's lock, appending matches to filteredLinesCache a batch at a time.
+// Before each write it checks generation against scanGeneration: a mismatch
+// means this scan was superseded (new filter, new lines, or an explicit
+// Cancel()), so it gives up without touching the cache.
+func (f *FilteringReader) scanInBackground(generation int, spec filterSpec, unfilteredLineCount int) {
+	t0 := time.Now()
+
+	// matched is owned by this goroutine and only ever grown by appending:
+	// each batch below publishes a copy of its slice header (cheap, O(1)) to
+	// filteredLinesCache rather than copying the accumulated elements
+	// (expensive, O(M)). Readers take the lock before looking at
+	// filteredLinesCache and only ever see the header they were handed, so
+	// later appends here - whether they grow matched in place or reallocate
+	// it - can't corrupt whatever a reader already has a copy of.
+	matched := make([]*reader.NumberedLine, 0)
+
+	for start := 0; start < unfilteredLineCount; start += scanBatchLines {
+		count := scanBatchLines
+		if start+count > unfilteredLineCount {
+			count = unfilteredLineCount - start
+		}
+
+		batch := f.BackingReader.GetLines(linemetadata.IndexFromZeroBased(start), count)
+		batchMatches := filterLinesParallel(batch.Lines, spec)
+
+		for _, line := range batchMatches {
+			matched = append(matched, &reader.NumberedLine{
+				Line:   line.Line,
+				Index:  linemetadata.IndexFromZeroBased(len(matched)),
+				Number: line.Number,
+			})
+		}
+
+		f.lock.Lock()
+		if f.scanGeneration != generation {
+			f.lock.Unlock()
+			return
+		}
+
+		published := matched
+		f.filteredLinesCache = &published
+		f.scanLinesScanned = start + count
+		f.lock.Unlock()
+	}
+
+	f.lock.Lock()
+	if f.scanGeneration == generation {
+		f.scanInProgress = false
+	}
+	f.lock.Unlock()
 
 	log.Debugf("Filtered out %d/%d lines in %s",
-		len(allBaseLines.Lines)-len(cache), len(allBaseLines.Lines), time.Since(t0))
+		unfilteredLineCount-len(matched), unfilteredLineCount, time.Since(t0))
+}
+
+// filterLinesParallel applies spec to lines, splitting the work into
+// runtime.NumCPU() contiguous chunks so that large inputs don't serialize the
+// whole match on a single core. The result preserves input order, with Index
+// renumbered to match its position in the filtered-down result.
+//
+// This mirrors how the pager's own first-hit search spreads its regex
+// matching across cores.
+func filterLinesParallel(lines []*reader.NumberedLine, spec filterSpec) []*reader.NumberedLine {
+	if len(lines) == 0 {
+		return make([]*reader.NumberedLine, 0)
+	}
+
+	chunkCount := runtime.NumCPU()
+	if chunkCount > len(lines) {
+		chunkCount = len(lines)
+	}
+	if chunkCount < 1 {
+		chunkCount = 1
+	}
+
+	chunkSize := (len(lines) + chunkCount - 1) / chunkCount
+	chunkResults := make([][]*reader.NumberedLine, chunkCount)
+
+	var wg sync.WaitGroup
+	for chunkIndex := 0; chunkIndex < chunkCount; chunkIndex++ {
+		start := chunkIndex * chunkSize
+		end := start + chunkSize
+		if end > len(lines) {
+			end = len(lines)
+		}
+		if start >= end {
+			continue
+		}
+
+		wg.Add(1)
+		go func(chunkIndex int, chunk []*reader.NumberedLine) {
+			defer wg.Done()
+
+			matched := make([]*reader.NumberedLine, 0, len(chunk))
+			for _, line := range chunk {
+				if !spec.matches(line.Line.Plain(&line.Index)) {
+					continue
+				}
+				matched = append(matched, line)
+			}
+			chunkResults[chunkIndex] = matched
+		}(chunkIndex, lines[start:end])
+	}
+	wg.Wait()
+
+	merged := make([]*reader.NumberedLine, 0, len(lines))
+	for _, chunk := range chunkResults {
+		merged = append(merged, chunk...)
+	}
+
+	cache := make([]*reader.NumberedLine, len(merged))
+	for resultIndex, line := range merged {
+		cache[resultIndex] = &reader.NumberedLine{
+			Line:   line.Line,
+			Index:  linemetadata.IndexFromZeroBased(resultIndex),
+			Number: line.Number,
+		}
+	}
+
+	return cache
 }
 
 func (f *FilteringReader) getAllLines() []*reader.NumberedLine {
@@ -86,15 +586,12 @@ func (f *FilteringReader) getAllLines() []*reader.NumberedLine {
 		return *f.filteredLinesCache
 	}
 
-	var currentFilterPattern string
-	if *f.FilterPattern != nil {
-		currentFilterPattern = (*f.FilterPattern).String()
-	}
-	var cacheFilterPattern string
-	if f.filterPatternWhenCaching != nil {
-		cacheFilterPattern = f.filterPatternWhenCaching.String()
+	if !f.currentFilterSpec().sameAs(f.filterSpecWhenCaching) {
+		f.rebuildCache()
+		return *f.filteredLinesCache
 	}
-	if currentFilterPattern != cacheFilterPattern {
+
+	if f.ContextBefore != f.contextBeforeWhenCaching || f.ContextAfter != f.contextAfterWhenCaching {
 		f.rebuildCache()
 		return *f.filteredLinesCache
 	}
@@ -107,8 +604,11 @@ func (f *FilteringReader) shouldPassThrough() bool {
 	defer f.lock.Unlock()
 
 	if *f.FilterPattern == nil || len((*f.FilterPattern).String()) == 0 {
-		// Cache is not needed
+		// Cache is not needed. Invert/CaseInsensitive are moot without a
+		// pattern to apply them to, so there's nothing to filter either way.
 		f.filteredLinesCache = nil
+		f.scanGeneration++
+		f.scanInProgress = false
 
 		// No filtering, so pass through all
 		return true
@@ -176,6 +676,10 @@ func (f *FilteringReader) GetLines(firstLine linemetadata.Index, wantedLineCount
 
 // In the general case, this will return a text like this:
 // "Filtered: 1234/5678 lines  22%"
+//
+// While a background scan is still filling in the cache, this instead
+// returns something like:
+// "Filtered: 1234/5678 lines (filtering… 42%)"
 func (f *FilteringReader) createStatus(lastLine *linemetadata.Index) string {
 	baseCount := f.BackingReader.GetLineCount()
 	if baseCount == 0 {
@@ -187,6 +691,22 @@ func (f *FilteringReader) createStatus(lastLine *linemetadata.Index) string {
 		baseCountString = ""
 	}
 
+	f.lock.Lock()
+	scanInProgress := f.scanInProgress
+	scanLinesScanned := f.scanLinesScanned
+	scanLinesTotal := f.scanLinesTotal
+	f.lock.Unlock()
+
+	if scanInProgress {
+		acceptedCountString := linemetadata.IndexFromLength(f.GetLineCount()).Format()
+		scanPercent := 0
+		if scanLinesTotal > 0 {
+			scanPercent = int(math.Floor(100 * float64(scanLinesScanned) / float64(scanLinesTotal)))
+		}
+		return fmt.Sprintf("Filtered: %s%s lines (filtering… %d%%)",
+			acceptedCountString, baseCountString, scanPercent)
+	}
+
 	if lastLine == nil {
 		// 100% because we're showing all 0 lines
 		return "Filtered: 0" + baseCountString + " lines  100%"
@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/walles/moor/v2/internal/linemetadata"
+	"github.com/walles/moor/v2/internal/reader"
+)
+
+func makeBenchLines(n int) []*reader.NumberedLine {
+	lines := make([]*reader.NumberedLine, n)
+	for i := 0; i < n; i++ {
+		lines[i] = &reader.NumberedLine{
+			Line:  *linemetadata.NewLine(fmt.Sprintf("this is line %d of the file", i)),
+			Index: linemetadata.IndexFromZeroBased(i),
+		}
+	}
+	return lines
+}
+
+func filterLinesSerial(lines []*reader.NumberedLine, spec filterSpec) []*reader.NumberedLine {
+	cache := make([]*reader.NumberedLine, 0, len(lines))
+	resultIndex := 0
+	for _, line := range lines {
+		if !spec.matches(line.Line.Plain(&line.Index)) {
+			continue
+		}
+		cache = append(cache, &reader.NumberedLine{
+			Line:   line.Line,
+			Index:  linemetadata.IndexFromZeroBased(resultIndex),
+			Number: line.Number,
+		})
+		resultIndex++
+	}
+	return cache
+}
+
+// BenchmarkFilterSerial1M and BenchmarkFilterParallel1M compare the old
+// single-goroutine scan against filterLinesParallel on a synthetic 1M-line
+// input, most of which doesn't match the pattern.
+func BenchmarkFilterSerial1M(b *testing.B) {
+	lines := makeBenchLines(1_000_000)
+	spec := filterSpec{pattern: regexp.MustCompile("line 999999")}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		filterLinesSerial(lines, spec)
+	}
+}
+
+func BenchmarkFilterParallel1M(b *testing.B) {
+	lines := makeBenchLines(1_000_000)
+	spec := filterSpec{pattern: regexp.MustCompile("line 999999")}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		filterLinesParallel(lines, spec)
+	}
+}
@@ -0,0 +1,361 @@
+package internal
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/walles/moor/v2/internal/linemetadata"
+	"github.com/walles/moor/v2/internal/reader"
+)
+
+// fakeReader is a minimal reader.Reader backed by a plain string slice, just
+// big enough to exercise FilteringReader's caching logic.
+type fakeReader struct {
+	lines []string
+}
+
+func (r *fakeReader) GetLineCount() int {
+	return len(r.lines)
+}
+
+func (r *fakeReader) ShouldShowLineCount() bool {
+	return true
+}
+
+func (r *fakeReader) GetLine(index linemetadata.Index) *reader.NumberedLine {
+	lines := r.GetLines(index, 1)
+	if len(lines.Lines) == 0 {
+		return nil
+	}
+	return lines.Lines[0]
+}
+
+func (r *fakeReader) GetLines(firstLine linemetadata.Index, wantedLineCount int) *reader.InputLines {
+	result := reader.InputLines{}
+	for i := firstLine.Index(); i < len(r.lines) && len(result.Lines) < wantedLineCount; i++ {
+		result.Lines = append(result.Lines, &reader.NumberedLine{
+			Line:  *linemetadata.NewLine(r.lines[i]),
+			Index: linemetadata.IndexFromZeroBased(i),
+		})
+	}
+	return &result
+}
+
+func newFilteringReader(lines []string, pattern *regexp.Regexp) (*FilteringReader, *fakeReader) {
+	backing := &fakeReader{lines: lines}
+	filterPattern := pattern
+	return &FilteringReader{
+		BackingReader: backing,
+		FilterPattern: &filterPattern,
+	}, backing
+}
+
+func plainLines(f *FilteringReader) []string {
+	all := f.GetLines(linemetadata.Index{}, f.GetLineCount())
+	result := make([]string, 0, len(all.Lines))
+	for _, line := range all.Lines {
+		result = append(result, line.Line.Plain(&line.Index))
+	}
+	return result
+}
+
+func TestFilteringReaderAppendedLines(t *testing.T) {
+	pattern := regexp.MustCompile("apple")
+	f, backing := newFilteringReader([]string{"apple pie", "banana split"}, pattern)
+
+	f.GetLineCount()
+	waitForScanDone(t, f)
+	if got := plainLines(f); len(got) != 1 || got[0] != "apple pie" {
+		t.Fatalf("got %v, want [apple pie]", got)
+	}
+
+	// Grow the backing reader without changing the pattern: this should hit
+	// the append-only path rather than a full rescan.
+	backing.lines = append(backing.lines, "apple sauce")
+	*f.FilterPattern = pattern
+
+	got := plainLines(f)
+	want := []string{"apple pie", "apple sauce"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestFilteringReaderPatternNarrowing(t *testing.T) {
+	pattern := regexp.MustCompile("apple")
+	f, _ := newFilteringReader([]string{"apple pie", "apple sauce", "banana split"}, pattern)
+
+	f.GetLineCount()
+	waitForScanDone(t, f)
+	if got := plainLines(f); len(got) != 2 {
+		t.Fatalf("got %v, want 2 lines", got)
+	}
+
+	// Narrow the pattern: "apple pie" should be the only survivor. "apple pie"
+	// is "apple" with a literal suffix appended, so this hits the narrowing
+	// fast path and filters down the existing cache instead of rescanning
+	// the backing reader.
+	narrower := regexp.MustCompile("apple pie")
+	*f.FilterPattern = narrower
+
+	f.GetLineCount()
+	waitForScanDone(t, f)
+	got := plainLines(f)
+	if len(got) != 1 || got[0] != "apple pie" {
+		t.Fatalf("got %v, want [apple pie]", got)
+	}
+}
+
+func TestIsNarrowingOf(t *testing.T) {
+	apple := filterSpec{pattern: regexp.MustCompile("apple")}
+	applePie := filterSpec{pattern: regexp.MustCompile("apple pie")}
+	if !isNarrowingOf(applePie, apple) {
+		t.Error("expected 'apple pie' to be recognized as a narrowing of 'apple'")
+	}
+
+	// "ab?" contains "ab" as a substring and has no anchors or alternation,
+	// but it's not a narrowing: it matches everything "ab" matches plus
+	// everything containing a lone "a". The appended suffix isn't a plain
+	// literal, so this must be rejected.
+	ab := filterSpec{pattern: regexp.MustCompile("ab")}
+	abQuestion := filterSpec{pattern: regexp.MustCompile("ab?")}
+	if isNarrowingOf(abQuestion, ab) {
+		t.Error("expected 'ab?' not to be recognized as a narrowing of 'ab'")
+	}
+
+	if isNarrowingOf(apple, applePie) {
+		t.Error("expected 'apple' not to be a narrowing of 'apple pie'")
+	}
+}
+
+func TestFilteringReaderPatternWidening(t *testing.T) {
+	pattern := regexp.MustCompile("apple pie")
+	f, _ := newFilteringReader([]string{"apple pie", "apple sauce", "banana split"}, pattern)
+
+	f.GetLineCount()
+	waitForScanDone(t, f)
+	if got := plainLines(f); len(got) != 1 {
+		t.Fatalf("got %v, want 1 line", got)
+	}
+
+	// Widen the pattern back out: this is not a narrowing of the old pattern,
+	// so it must fall back to a full rebuild against the backing reader.
+	wider := regexp.MustCompile("apple")
+	*f.FilterPattern = wider
+
+	f.GetLineCount()
+	waitForScanDone(t, f)
+	got := plainLines(f)
+	if len(got) != 2 {
+		t.Fatalf("got %v, want 2 lines", got)
+	}
+}
+
+// waitForScanDone polls until the background scan finishes, so tests don't
+// race against the goroutine started by startFullRebuild.
+func waitForScanDone(t *testing.T, f *FilteringReader) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		f.lock.Lock()
+		inProgress := f.scanInProgress
+		f.lock.Unlock()
+		if !inProgress {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("background scan never finished")
+}
+
+func TestFilteringReaderRestartsRatherThanAppendingMidScan(t *testing.T) {
+	pattern := regexp.MustCompile("apple")
+	f, _ := newFilteringReader([]string{"apple pie", "banana split"}, pattern)
+
+	// Simulate a background scan that has only gotten through the first
+	// backing line so far and is still running.
+	partial := make([]*reader.NumberedLine, 0)
+	f.filteredLinesCache = &partial
+	f.unfilteredLineCountWhenCaching = 1
+	f.filterSpecWhenCaching = f.currentFilterSpec()
+	f.scanInProgress = true
+
+	f.lock.Lock()
+	f.rebuildCache()
+	f.lock.Unlock()
+
+	// Appending [1, 2) onto the empty partial cache would have produced zero
+	// matches, silently losing "apple pie" at index 0. Gating the append
+	// path on scanInProgress should have restarted a full rebuild instead.
+	waitForScanDone(t, f)
+	got := plainLines(f)
+	if len(got) != 1 || got[0] != "apple pie" {
+		t.Fatalf("got %v, want [apple pie]", got)
+	}
+}
+
+func TestFilteringReaderBackgroundScanCompletes(t *testing.T) {
+	pattern := regexp.MustCompile("apple")
+	f, _ := newFilteringReader([]string{"apple pie", "banana split", "apple sauce"}, pattern)
+
+	// The first GetLines call kicks off a background scan and may return
+	// before it's done; GetLineCount should still reach the right answer
+	// once the scan finishes.
+	f.GetLines(linemetadata.Index{}, 1)
+	waitForScanDone(t, f)
+
+	if got := f.GetLineCount(); got != 2 {
+		t.Fatalf("got %d matching lines, want 2", got)
+	}
+}
+
+func TestFilteringReaderCancel(t *testing.T) {
+	pattern := regexp.MustCompile("apple")
+	f, _ := newFilteringReader([]string{"apple pie", "banana split"}, pattern)
+
+	f.GetLines(linemetadata.Index{}, 1)
+	f.Cancel()
+
+	f.lock.Lock()
+	inProgress := f.scanInProgress
+	f.lock.Unlock()
+	if inProgress {
+		t.Fatal("expected Cancel() to stop the in-progress scan")
+	}
+
+	// Mimic the pager swapping in a new pattern right after cancelling
+	// mid-scan: the next read should start a fresh scan against it rather
+	// than waiting for (or being corrupted by) the cancelled one.
+	narrower := regexp.MustCompile("apple pie")
+	*f.FilterPattern = narrower
+	f.GetLines(linemetadata.Index{}, 1)
+	waitForScanDone(t, f)
+
+	if got := f.GetLineCount(); got != 1 {
+		t.Fatalf("got %d matching lines, want 1", got)
+	}
+}
+
+func TestFilteringReaderCancelWithoutFilterChangeRebuildsFully(t *testing.T) {
+	pattern := regexp.MustCompile("apple")
+	f, _ := newFilteringReader([]string{"apple pie", "banana split", "apple sauce"}, pattern)
+
+	f.GetLines(linemetadata.Index{}, 1)
+	f.Cancel()
+
+	// Nothing about the filter or the backing reader changed after
+	// cancelling. The cancelled scan's partial cache must not be reported
+	// as a finished result: the next read has to rebuild it properly.
+	f.GetLineCount()
+	waitForScanDone(t, f)
+	got := plainLines(f)
+	if len(got) != 2 || got[0] != "apple pie" || got[1] != "apple sauce" {
+		t.Fatalf("got %v, want [apple pie apple sauce]", got)
+	}
+}
+
+func TestFilteringReaderInvert(t *testing.T) {
+	pattern := regexp.MustCompile("apple")
+	f, _ := newFilteringReader([]string{"apple pie", "banana split", "apple sauce"}, pattern)
+	f.Invert = true
+
+	f.GetLineCount()
+	waitForScanDone(t, f)
+	got := plainLines(f)
+	if len(got) != 1 || got[0] != "banana split" {
+		t.Fatalf("got %v, want [banana split]", got)
+	}
+}
+
+func TestFilteringReaderCaseInsensitive(t *testing.T) {
+	pattern := regexp.MustCompile("APPLE")
+	f, _ := newFilteringReader([]string{"apple pie", "banana split"}, pattern)
+	f.CaseInsensitive = true
+
+	f.GetLineCount()
+	waitForScanDone(t, f)
+	got := plainLines(f)
+	if len(got) != 1 || got[0] != "apple pie" {
+		t.Fatalf("got %v, want [apple pie]", got)
+	}
+}
+
+func TestFilteringReaderContextLines(t *testing.T) {
+	pattern := regexp.MustCompile("MATCH")
+	f, _ := newFilteringReader([]string{
+		"line 0",
+		"line 1 MATCH",
+		"line 2",
+		"line 3",
+		"line 4",
+		"line 5",
+		"line 6 MATCH",
+		"line 7",
+	}, pattern)
+	f.ContextBefore = 1
+	f.ContextAfter = 1
+
+	got := plainLines(f)
+	want := []string{"line 0", "line 1 MATCH", "line 2", "--", "line 5", "line 6 MATCH", "line 7"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFilteringReaderContextLinesCoalesce(t *testing.T) {
+	pattern := regexp.MustCompile("MATCH")
+	f, _ := newFilteringReader([]string{
+		"line 0 MATCH",
+		"line 1",
+		"line 2",
+		"line 3 MATCH",
+		"line 4",
+	}, pattern)
+	f.ContextBefore = 2
+	f.ContextAfter = 2
+
+	// The two matches' context windows overlap, so this should come back as
+	// one contiguous group with no separator.
+	got := plainLines(f)
+	if len(got) != 5 {
+		t.Fatalf("got %v, want all 5 lines with no separator", got)
+	}
+	for _, line := range got {
+		if line == "--" {
+			t.Fatalf("did not expect a separator in %v", got)
+		}
+	}
+}
+
+func TestLineMatchesLiteralPrefix(t *testing.T) {
+	spec := filterSpec{pattern: regexp.MustCompile("^ERROR:")}
+	if spec.matches("INFO: all good") {
+		t.Error("expected no match")
+	}
+	if !spec.matches("ERROR: it broke") {
+		t.Error("expected a match")
+	}
+}
+
+// TestLineMatchesFullyAnchoredLiteral covers a pattern whose entire source is
+// its literal prefix (LiteralPrefix's complete=true), which must not be
+// confused with an unanchored literal search: "^ERROR$" should only match the
+// exact line "ERROR", not any line merely containing that text.
+func TestLineMatchesFullyAnchoredLiteral(t *testing.T) {
+	spec := filterSpec{pattern: regexp.MustCompile("^ERROR$")}
+	if spec.matches("xERRORx") {
+		t.Error("expected no match for a line that only contains ERROR as a substring")
+	}
+	if spec.matches("ERROR ") {
+		t.Error("expected no match for a line with trailing text after ERROR")
+	}
+	if !spec.matches("ERROR") {
+		t.Error("expected a match for the exact line ERROR")
+	}
+}
@@ -1,5 +1,10 @@
 package twin
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Used for testing.
 //
 // Try GetRow() after some SetCell() calls to see what you got.
@@ -96,3 +101,58 @@ func (screen *FakeScreen) Events() chan Event {
 func (screen *FakeScreen) GetRow(row int) []StyledRune {
 	return withoutHiddenRunes(screen.cells[row])
 }
+
+// GetRowString returns a row's contents as plain text, with all styling
+// stripped. This is what most tests actually want to assert on instead of
+// reconstructing the string from GetRow() themselves.
+func (screen *FakeScreen) GetRowString(row int) string {
+	var sb strings.Builder
+	for _, styledRune := range screen.GetRow(row) {
+		sb.WriteRune(styledRune.Rune())
+	}
+	return sb.String()
+}
+
+// GetRowANSI returns a row's contents as text with embedded SGR escape
+// sequences, so that style changes within the row are visible too. This uses
+// the same style-diffing that UnixScreen uses when writing to the real
+// terminal, so two adjacent runs with identical styling don't get redundant
+// escape codes between them.
+func (screen *FakeScreen) GetRowANSI(row int) string {
+	var sb strings.Builder
+
+	previousStyle := StyleDefault
+	for _, styledRune := range screen.GetRow(row) {
+		sb.WriteString(styledRune.Style.RenderUpdateFrom(previousStyle))
+		sb.WriteRune(styledRune.Rune())
+		previousStyle = styledRune.Style
+	}
+	sb.WriteString(StyleDefault.RenderUpdateFrom(previousStyle))
+
+	return sb.String()
+}
+
+// Diff returns a human readable description of how screen and other differ,
+// or an empty string if their contents are identical. Differences are
+// reported one row at a time using GetRowString(), so that test failure
+// messages show what's actually on screen rather than a wall of
+// rune-by-rune comparisons.
+func (screen *FakeScreen) Diff(other *FakeScreen) string {
+	width, height := screen.Size()
+	otherWidth, otherHeight := other.Size()
+	if width != otherWidth || height != otherHeight {
+		return fmt.Sprintf("size mismatch: %dx%d vs %dx%d", width, height, otherWidth, otherHeight)
+	}
+
+	var lines []string
+	for row := 0; row < height; row++ {
+		ourLine := screen.GetRowString(row)
+		otherLine := other.GetRowString(row)
+		if ourLine == otherLine {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("row %d:\n-%s\n+%s", row, ourLine, otherLine))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,57 @@
+package twin
+
+import "testing"
+
+func TestGetRowString(t *testing.T) {
+	screen := NewFakeScreen(5, 1)
+	screen.Clear()
+	screen.SetCell(0, 0, NewStyledRune('h', StyleDefault))
+	screen.SetCell(1, 0, NewStyledRune('i', StyleDefault))
+
+	if got := screen.GetRowString(0); got != "hi   " {
+		t.Errorf("got %q, want %q", got, "hi   ")
+	}
+}
+
+func TestGetRowANSI(t *testing.T) {
+	screen := NewFakeScreen(2, 1)
+	screen.SetCell(0, 0, NewStyledRune('a', StyleDefault))
+	screen.SetCell(1, 0, NewStyledRune('b', StyleDefault))
+
+	got := screen.GetRowANSI(0)
+	if got != "ab" {
+		t.Errorf("expected unstyled text to round-trip with no escape codes, got %q", got)
+	}
+}
+
+func TestDiffIdentical(t *testing.T) {
+	a := NewFakeScreen(3, 2)
+	b := NewFakeScreen(3, 2)
+	a.SetCell(0, 0, NewStyledRune('x', StyleDefault))
+	b.SetCell(0, 0, NewStyledRune('x', StyleDefault))
+
+	if diff := a.Diff(b); diff != "" {
+		t.Errorf("expected no diff between identical screens, got %q", diff)
+	}
+}
+
+func TestDiffMismatch(t *testing.T) {
+	a := NewFakeScreen(3, 1)
+	b := NewFakeScreen(3, 1)
+	a.SetCell(0, 0, NewStyledRune('x', StyleDefault))
+	b.SetCell(0, 0, NewStyledRune('y', StyleDefault))
+
+	diff := a.Diff(b)
+	if diff == "" {
+		t.Fatal("expected a diff between screens with different contents")
+	}
+}
+
+func TestDiffSizeMismatch(t *testing.T) {
+	a := NewFakeScreen(3, 1)
+	b := NewFakeScreen(4, 1)
+
+	if diff := a.Diff(b); diff == "" {
+		t.Fatal("expected a diff between screens of different sizes")
+	}
+}